@@ -1,26 +1,67 @@
 package main
 
 import (
+	"bytes"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"math/rand"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli/v2"
 	"gopkg.in/yaml.v2"
 )
 
+// maxConcurrentFetches bounds how many repositories are scanned in parallel
+// when aggregating alerts across an organization or an explicit repo list.
+const maxConcurrentFetches = 8
+
+// defaultPerPage is the page size requested from the alerts endpoints; 100
+// is the maximum GitHub allows.
+const defaultPerPage = 100
+
+// maxRetries bounds how many times a single page fetch is retried after a
+// 5xx response before giving up.
+const maxRetries = 5
+
+// alertFilters holds the optional server-side filters applied to alert
+// list requests, so pages that don't match never cross the wire.
+type alertFilters struct {
+	Severity  string
+	Ecosystem string
+	Scope     string
+}
+
+func (f alertFilters) apply(query url.Values) {
+	if f.Severity != "" {
+		query.Set("severity", f.Severity)
+	}
+	if f.Ecosystem != "" {
+		query.Set("ecosystem", f.Ecosystem)
+	}
+	if f.Scope != "" {
+		query.Set("scope", f.Scope)
+	}
+}
+
 type Config struct {
 	Token        string `yaml:"github_token"`
 	OutputFormat string `yaml:"output_format"`
 }
 
 type DependabotAlert struct {
+	Number     int `json:"number"`
 	Dependency struct {
 		Package struct {
 			Name      string `json:"name"`
@@ -30,15 +71,43 @@ type DependabotAlert struct {
 		Scope        string `json:"scope"`
 	} `json:"dependency"`
 	SecurityAdvisory struct {
+		GHSAID      string `json:"ghsa_id"`
 		Severity    string `json:"severity"`
 		Description string `json:"description"`
 		Identifiers []struct {
 			Type  string `json:"type"`
 			Value string `json:"value"`
 		} `json:"identifiers"`
+		CVSS struct {
+			Score        float64 `json:"score"`
+			VectorString string  `json:"vector_string"`
+		} `json:"cvss"`
+		CWEs []struct {
+			CWEID string `json:"cwe_id"`
+			Name  string `json:"name"`
+		} `json:"cwes"`
 	} `json:"security_advisory"`
-	HTMLURL string `json:"html_url"`
-	State   string `json:"state"`
+	SecurityVulnerability struct {
+		VulnerableVersionRange string `json:"vulnerable_version_range"`
+		FirstPatchedVersion    struct {
+			Identifier string `json:"identifier"`
+		} `json:"first_patched_version"`
+	} `json:"security_vulnerability"`
+	HTMLURL   string `json:"html_url"`
+	State     string `json:"state"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+
+	DismissedAt     string `json:"dismissed_at"`
+	DismissedReason string `json:"dismissed_reason"`
+	DismissedBy     struct {
+		Login string `json:"login"`
+	} `json:"dismissed_by"`
+
+	// Repository is not part of the GitHub API response for a single-repo
+	// fetch; it is stamped onto each alert after fetching so multi-repo and
+	// organization-wide reports can tell alerts apart.
+	Repository string `json:"repository"`
 }
 
 func main() {
@@ -56,20 +125,74 @@ func main() {
 			&cli.StringFlag{
 				Name:    "output",
 				Aliases: []string{"o"},
-				Usage:   "Output format (json or csv)",
+				Usage:   "Output format (json, csv, sarif, or table)",
 				EnvVars: []string{"OUTPUT_FORMAT"},
 			},
 			&cli.StringFlag{
-				Name:     "repo",
-				Aliases:  []string{"r"},
-				Usage:    "Repository in owner/repo format",
-				Required: true,
+				Name:  "output-file",
+				Usage: "Write the report to this path instead of an auto-generated one under reports/",
+			},
+			&cli.StringFlag{
+				Name:    "repo",
+				Aliases: []string{"r"},
+				Usage:   "Repository in owner/repo format",
+			},
+			&cli.StringFlag{
+				Name:  "repos",
+				Usage: "Comma-separated list of repositories in owner/repo format",
+			},
+			&cli.StringFlag{
+				Name:  "org",
+				Usage: "Organization login to scan all accessible repositories for",
+			},
+			&cli.BoolFlag{
+				Name:  "actions",
+				Usage: "Emit GitHub Actions workflow commands and a job summary (auto-enabled when GITHUB_ACTIONS=true)",
+			},
+			&cli.StringFlag{
+				Name:  "fail-on",
+				Usage: "Exit non-zero when alerts at or above this severity are found (low, medium, high, critical)",
+			},
+			&cli.StringFlag{
+				Name:  "severity",
+				Usage: "Comma-separated list of severities to include (e.g. critical,high)",
+			},
+			&cli.StringFlag{
+				Name:  "ecosystem",
+				Usage: "Comma-separated list of ecosystems to include (e.g. npm,pip)",
+			},
+			&cli.StringFlag{
+				Name:  "scope",
+				Usage: "Only include alerts with this dependency scope (e.g. runtime)",
+			},
+			&cli.StringFlag{
+				Name:  "manifest-glob",
+				Usage: "Only include alerts whose manifest path matches this glob (e.g. **/package.json)",
+			},
+			&cli.StringFlag{
+				Name:  "baseline",
+				Usage: "Path to a previous JSON report to diff the current alerts against",
+			},
+			&cli.BoolFlag{
+				Name:  "diff-only",
+				Usage: "Report only new/resolved alerts relative to --baseline, instead of the full alert list",
 			},
 		},
 		Action: func(c *cli.Context) error {
 			configFile := c.String("config")
 			outputFormat := c.String("output")
 			repo := c.String("repo")
+			org := c.String("org")
+			repos := splitAndTrim(c.String("repos"))
+			actionsMode := c.Bool("actions") || os.Getenv("GITHUB_ACTIONS") == "true"
+			failOn := c.String("fail-on")
+			outputFile := c.String("output-file")
+			severities := splitAndTrim(c.String("severity"))
+			ecosystems := splitAndTrim(c.String("ecosystem"))
+			scope := c.String("scope")
+			manifestGlob := c.String("manifest-glob")
+			baselineFile := c.String("baseline")
+			diffOnly := c.Bool("diff-only")
 
 			config := loadConfig(configFile)
 
@@ -81,18 +204,74 @@ func main() {
 				config.OutputFormat = "json"
 			}
 
-			if config.OutputFormat != "json" && config.OutputFormat != "csv" {
-				return fmt.Errorf("unsupported output format: use 'json' or 'csv'")
+			if config.OutputFormat != "json" && config.OutputFormat != "csv" && config.OutputFormat != "sarif" && config.OutputFormat != "table" {
+				return fmt.Errorf("unsupported output format: use 'json', 'csv', 'sarif', or 'table'")
 			}
 
 			if config.Token == "" {
 				return fmt.Errorf("GitHub personal access token is required. Set it in your config file or as the DEPENDABOT_TOKEN environment variable")
 			}
 
-			log.Printf("Fetching alerts from repository %s...", repo)
-			alerts := fetchDependabotAlerts(config.Token, repo)
+			if diffOnly && baselineFile == "" {
+				return fmt.Errorf("--diff-only requires --baseline")
+			}
+
+			if repo == "" && org == "" && len(repos) == 0 {
+				return fmt.Errorf("one of --repo, --repos, or --org is required")
+			}
+
+			filters := alertFilters{
+				Severity:  strings.ToLower(strings.Join(severities, ",")),
+				Ecosystem: strings.ToLower(strings.Join(ecosystems, ",")),
+				Scope:     scope,
+			}
+
+			var alerts []DependabotAlert
+			var reportName string
 
-			if len(alerts) == 0 {
+			switch {
+			case org != "":
+				log.Printf("Fetching alerts for organization %s...", org)
+				alerts = fetchOrgDependabotAlerts(config.Token, org, filters)
+				reportName = org
+			case len(repos) > 0:
+				log.Printf("Fetching alerts from %d repositories...", len(repos))
+				alerts = fetchReposDependabotAlerts(config.Token, repos, filters)
+				reportName = "multi-repo"
+			default:
+				log.Printf("Fetching alerts from repository %s...", repo)
+				alerts = fetchDependabotAlerts(config.Token, repo, filters)
+				reportName = repo
+			}
+
+			filtered, err := filterAlerts(alerts, manifestGlob)
+			if err != nil {
+				return err
+			}
+			alerts = filtered
+
+			if actionsMode {
+				emitActionsWorkflowCommands(alerts)
+				if err := writeActionsOutputs(alerts); err != nil {
+					log.Printf("Warning: failed to write $GITHUB_OUTPUT: %v", err)
+				}
+				if err := writeActionsSummary(alerts); err != nil {
+					log.Printf("Warning: failed to write $GITHUB_STEP_SUMMARY: %v", err)
+				}
+			}
+
+			var diff *alertDiff
+			if baselineFile != "" {
+				baseline, err := loadBaseline(baselineFile)
+				if err != nil {
+					return err
+				}
+				computed := diffAlerts(baseline, alerts)
+				diff = &computed
+				log.Printf("Baseline diff: %d new, %d resolved", len(diff.NewAlerts), len(diff.ResolvedAlerts))
+			}
+
+			if len(alerts) == 0 && (diff == nil || len(diff.ResolvedAlerts) == 0) {
 				fmt.Println("No open Dependabot alerts found. Congratulations! :)")
 				return nil
 			}
@@ -100,11 +279,39 @@ func main() {
 			log.Printf("Found %d open Dependabot alerts!", len(alerts))
 			log.Printf("Exporting alerts to %s format...", config.OutputFormat)
 
-			switch config.OutputFormat {
-			case "json":
-				exportJSON(alerts, repo)
-			case "csv":
-				exportCSV(alerts, repo)
+			if diffOnly && diff != nil {
+				switch config.OutputFormat {
+				case "json":
+					exportDiffJSON(*diff, reportName, outputFile)
+				case "csv":
+					exportDiffCSV(*diff, reportName, outputFile)
+				default:
+					log.Printf("--diff-only only supports json/csv output; exporting new alerts as %s", config.OutputFormat)
+					exportAlerts(config.OutputFormat, diff.NewAlerts, reportName, outputFile)
+				}
+			} else {
+				exportAlerts(config.OutputFormat, alerts, reportName, outputFile)
+			}
+
+			if failOn != "" {
+				if _, ok := severityRank[failOn]; !ok {
+					return fmt.Errorf("unsupported --fail-on severity: %s", failOn)
+				}
+
+				checkedAlerts := alerts
+				if diff != nil {
+					checkedAlerts = diff.NewAlerts
+				}
+
+				var matched int
+				for _, alert := range checkedAlerts {
+					if severityAtLeast(alert.SecurityAdvisory.Severity, failOn) {
+						matched++
+					}
+				}
+				if matched > 0 {
+					return cli.Exit(fmt.Sprintf("%d alert(s) at or above severity %q", matched, failOn), 1)
+				}
 			}
 
 			return nil
@@ -134,51 +341,452 @@ func loadConfig(path string) Config {
 	return config
 }
 
-func fetchDependabotAlerts(token, repo string) []DependabotAlert {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/dependabot/alerts", repo)
-	req, err := http.NewRequest("GET", url, nil)
+// severityRank orders Dependabot severities from least to most severe, so
+// --fail-on can treat it as a threshold rather than an exact match.
+var severityRank = map[string]int{
+	"low":      0,
+	"medium":   1,
+	"high":     2,
+	"critical": 3,
+}
+
+func severityAtLeast(severity, threshold string) bool {
+	return severityRank[severity] >= severityRank[threshold]
+}
+
+// emitActionsWorkflowCommands prints one GitHub Actions workflow command
+// per alert, annotating the offending manifest directly in the workflow
+// run's log and, for critical/high alerts, the checks UI.
+func emitActionsWorkflowCommands(alerts []DependabotAlert) {
+	for _, alert := range alerts {
+		command := "warning"
+		if severityAtLeast(alert.SecurityAdvisory.Severity, "high") {
+			command = "error"
+		}
+		cve := getCVE(alert.SecurityAdvisory.Identifiers)
+		fmt.Printf("::%s file=%s::%s %s %s\n", command, alert.Dependency.ManifestPath, alert.Dependency.Package.Name, alert.SecurityAdvisory.Severity, cve)
+	}
+}
+
+// writeActionsOutputs sets step outputs via $GITHUB_OUTPUT, using the
+// multiline heredoc format for alerts_json since it may contain newlines.
+// It is a no-op outside of a GitHub Actions runner.
+func writeActionsOutputs(alerts []DependabotAlert) error {
+	path := os.Getenv("GITHUB_OUTPUT")
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	alertsJSON, err := json.Marshal(alerts)
+	if err != nil {
+		return err
+	}
+
+	counts := countBySeverity(alerts)
+
+	writeMultilineOutput(file, "alerts_json", string(alertsJSON))
+	fmt.Fprintf(file, "total_count=%d\n", len(alerts))
+	fmt.Fprintf(file, "critical_count=%d\n", counts["critical"])
+	fmt.Fprintf(file, "high_count=%d\n", counts["high"])
+
+	return nil
+}
+
+// writeMultilineOutput appends a GitHub Actions step output using the
+// name<<DELIM\n...\nDELIM heredoc format required for values that may
+// contain newlines.
+func writeMultilineOutput(w io.Writer, name, value string) {
+	delimiter := "EOF_" + strings.ToUpper(name)
+	fmt.Fprintf(w, "%s<<%s\n%s\n%s\n", name, delimiter, value, delimiter)
+}
+
+func countBySeverity(alerts []DependabotAlert) map[string]int {
+	counts := map[string]int{}
+	for _, alert := range alerts {
+		counts[alert.SecurityAdvisory.Severity]++
+	}
+	return counts
+}
+
+// writeActionsSummary appends a Markdown table of alerts, grouped by
+// severity, to $GITHUB_STEP_SUMMARY. It is a no-op outside of a GitHub
+// Actions runner.
+func writeActionsSummary(alerts []DependabotAlert) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
-		log.Fatalf("Error creating request: %v", err)
+		return err
+	}
+	defer file.Close()
+
+	fmt.Fprintln(file, "## Dependabot Alerts")
+	fmt.Fprintln(file)
+
+	if len(alerts) == 0 {
+		fmt.Fprintln(file, "No open Dependabot alerts found.")
+		return nil
 	}
+
+	fmt.Fprintln(file, "| Severity | Package | Ecosystem | CVE | Manifest |")
+	fmt.Fprintln(file, "| --- | --- | --- | --- | --- |")
+
+	for _, severity := range []string{"critical", "high", "medium", "low"} {
+		for _, alert := range alerts {
+			if alert.SecurityAdvisory.Severity != severity {
+				continue
+			}
+			fmt.Fprintf(file, "| %s | [%s](%s) | %s | %s | %s |\n",
+				severity,
+				alert.Dependency.Package.Name,
+				alert.HTMLURL,
+				alert.Dependency.Package.Ecosystem,
+				getCVE(alert.SecurityAdvisory.Identifiers),
+				alert.Dependency.ManifestPath,
+			)
+		}
+	}
+
+	return nil
+}
+
+func fetchDependabotAlerts(token, repo string, filters alertFilters) []DependabotAlert {
+	baseURL := fmt.Sprintf("https://api.github.com/repos/%s/dependabot/alerts", repo)
+	query := url.Values{}
+	query.Set("state", "open")
+	query.Set("per_page", strconv.Itoa(defaultPerPage))
+	filters.apply(query)
+
+	var alerts []DependabotAlert
+	for page := range fetchPages(token, baseURL, query) {
+		var batch []DependabotAlert
+		if err := json.Unmarshal(page, &batch); err != nil {
+			log.Fatalf("Error decoding response: %v", err)
+		}
+		for _, alert := range batch {
+			alert.Repository = repo
+			alerts = append(alerts, alert)
+		}
+	}
+
+	return alerts
+}
+
+// fetchOrgDependabotAlerts fetches open Dependabot alerts across every
+// repository in an organization via the organization-wide endpoint, as
+// exposed by go-github's dependabot alerts support.
+func fetchOrgDependabotAlerts(token, org string, filters alertFilters) []DependabotAlert {
+	baseURL := fmt.Sprintf("https://api.github.com/orgs/%s/dependabot/alerts", org)
+	query := url.Values{}
+	query.Set("state", "open")
+	query.Set("per_page", strconv.Itoa(defaultPerPage))
+	filters.apply(query)
+
+	var alerts []DependabotAlert
+	for page := range fetchPages(token, baseURL, query) {
+		var batch []struct {
+			DependabotAlert
+			Repository struct {
+				FullName string `json:"full_name"`
+			} `json:"repository"`
+		}
+		if err := json.Unmarshal(page, &batch); err != nil {
+			log.Fatalf("Error decoding response: %v", err)
+		}
+		for _, raw := range batch {
+			alert := raw.DependabotAlert
+			alert.Repository = raw.Repository.FullName
+			alerts = append(alerts, alert)
+		}
+	}
+
+	return alerts
+}
+
+// fetchReposDependabotAlerts fetches alerts for an explicit list of
+// repositories, scanning up to maxConcurrentFetches of them at a time so
+// aggregating dozens of repos stays fast.
+func fetchReposDependabotAlerts(token string, repos []string, filters alertFilters) []DependabotAlert {
+	type result struct {
+		alerts []DependabotAlert
+	}
+
+	jobs := make(chan string)
+	results := make(chan result)
+
+	var wg sync.WaitGroup
+	workers := maxConcurrentFetches
+	if workers > len(repos) {
+		workers = len(repos)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range jobs {
+				results <- result{alerts: fetchDependabotAlerts(token, repo, filters)}
+			}
+		}()
+	}
+
+	go func() {
+		for _, repo := range repos {
+			jobs <- repo
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var allAlerts []DependabotAlert
+	for r := range results {
+		allAlerts = append(allAlerts, r.alerts...)
+	}
+
+	return allAlerts
+}
+
+// fetchPages walks an alerts endpoint page by page, following the RFC 5988
+// Link: rel="next" header, and streams each page's raw JSON array back on
+// the returned channel. The initial query (state, per_page, filters) is
+// only applied to the first request; subsequent requests reuse the "next"
+// URL GitHub hands back verbatim.
+func fetchPages(token, baseURL string, query url.Values) <-chan json.RawMessage {
+	out := make(chan json.RawMessage)
+
+	go func() {
+		defer close(out)
+
+		nextURL := baseURL + "?" + query.Encode()
+		for nextURL != "" {
+			req, err := http.NewRequest("GET", nextURL, nil)
+			if err != nil {
+				log.Fatalf("Error creating request: %v", err)
+			}
+
+			resp := doWithRetry(token, req)
+			var page json.RawMessage
+			if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+				resp.Body.Close()
+				log.Fatalf("Error decoding response: %v", err)
+			}
+
+			link := resp.Header.Get("Link")
+			resp.Body.Close()
+
+			out <- page
+			nextURL = parseNextLink(link)
+		}
+	}()
+
+	return out
+}
+
+// doWithRetry performs req, transparently handling GitHub's rate limiting
+// and transient server errors: it honors Retry-After on secondary rate
+// limits, sleeps until reset when the primary rate limit is exhausted, and
+// retries 5xx responses with exponential backoff and jitter. It returns the
+// first successful (200) response, or terminates the program if req keeps
+// failing after maxRetries attempts.
+func doWithRetry(token string, req *http.Request) *http.Response {
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Accept", "application/vnd.github+json")
 	req.Header.Set("Cache-Control", "no-cache")
 
 	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		log.Fatalf("Error making request: %v", err)
-	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	serverErrorRetries := 0
+	for {
+		resp, err := client.Do(req)
+		if err != nil {
+			log.Fatalf("Error making request: %v", err)
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			return resp
+		}
+
+		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+			if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+				wait := parseRetryAfter(retryAfter)
+				resp.Body.Close()
+				log.Printf("Secondary rate limit hit, waiting %s before retrying...", wait)
+				time.Sleep(wait)
+				continue
+			}
+
+			if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+				reset := parseRateLimitReset(resp.Header.Get("X-RateLimit-Reset"))
+				resp.Body.Close()
+				if wait := time.Until(reset); wait > 0 {
+					log.Printf("Rate limit exhausted, sleeping until reset at %s...", reset.Format(time.RFC3339))
+					time.Sleep(wait)
+				}
+				continue
+			}
+		}
+
+		if resp.StatusCode >= 500 && serverErrorRetries < maxRetries {
+			backoff := exponentialBackoff(serverErrorRetries)
+			serverErrorRetries++
+			resp.Body.Close()
+			log.Printf("Server error %s, retrying in %s (attempt %d/%d)...", resp.Status, backoff, serverErrorRetries, maxRetries)
+			time.Sleep(backoff)
+			continue
+		}
+
 		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
 		log.Fatalf("Error fetching alerts: %v\nResponse: %s", resp.Status, string(body))
 	}
+}
 
-	var allAlerts []DependabotAlert
-	err = json.NewDecoder(resp.Body).Decode(&allAlerts)
+// parseNextLink extracts the URL for rel="next" out of a Link header,
+// returning "" once there are no further pages.
+func parseNextLink(header string) string {
+	if header == "" {
+		return ""
+	}
+
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+
+		url := strings.TrimSpace(parts[0])
+		url = strings.Trim(url, "<>")
+
+		for _, param := range parts[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return url
+			}
+		}
+	}
+
+	return ""
+}
+
+// parseRetryAfter interprets a Retry-After header value, which GitHub sends
+// either as a number of seconds or an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		return time.Until(when)
+	}
+	return time.Second
+}
+
+// parseRateLimitReset turns the X-RateLimit-Reset header (a Unix epoch
+// seconds timestamp) into a time.Time.
+func parseRateLimitReset(value string) time.Time {
+	epoch, err := strconv.ParseInt(value, 10, 64)
 	if err != nil {
-		log.Fatalf("Error decoding response: %v", err)
+		return time.Now().Add(time.Minute)
 	}
+	return time.Unix(epoch, 0)
+}
+
+// exponentialBackoff returns the wait before retrying a 5xx response:
+// 2^attempt seconds plus up to one second of jitter, to avoid every
+// in-flight retry landing on the API at the same instant.
+func exponentialBackoff(attempt int) time.Duration {
+	base := time.Duration(1<<attempt) * time.Second
+	jitter := time.Duration(rand.Int63n(int64(time.Second)))
+	return base + jitter
+}
 
-	openAlerts := []DependabotAlert{}
-	for _, alert := range allAlerts {
-		if alert.State == "open" {
-			openAlerts = append(openAlerts, alert)
+// splitAndTrim splits a comma-separated flag value into a cleaned list,
+// dropping empty entries produced by stray whitespace or trailing commas.
+func splitAndTrim(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	var out []string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			out = append(out, part)
 		}
 	}
 
-	return openAlerts
+	return out
 }
 
-func exportJSON(alerts []DependabotAlert, repo string) {
+// filterAlerts narrows alerts down to those matching manifestGlob.
+// Severity/ecosystem/scope are filtered server-side via alertFilters; an
+// empty manifestGlob is treated as "no constraint".
+func filterAlerts(alerts []DependabotAlert, manifestGlob string) ([]DependabotAlert, error) {
+	if manifestGlob == "" {
+		return alerts, nil
+	}
+
+	filtered := make([]DependabotAlert, 0, len(alerts))
+
+	for _, alert := range alerts {
+		matched, err := doublestar.Match(manifestGlob, alert.Dependency.ManifestPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --manifest-glob: %w", err)
+		}
+		if matched {
+			filtered = append(filtered, alert)
+		}
+	}
+
+	return filtered, nil
+}
+
+// reportFilePath resolves where a report should be written: outputFile if
+// the caller specified one (creating its parent directory if needed), or an
+// auto-generated, timestamped path under reports/ otherwise.
+func reportFilePath(outputFile, repo, ext string) string {
+	if outputFile != "" {
+		if dir := filepath.Dir(outputFile); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				log.Fatalf("Error creating output directory: %v", err)
+			}
+		}
+		return outputFile
+	}
+
 	repoName := filepath.Base(repo)
 	timestamp := time.Now().Format("20060102-150405")
-	filename := fmt.Sprintf("%s-alerts-%s.json", repoName, timestamp)
+	filename := fmt.Sprintf("%s-alerts-%s.%s", repoName, timestamp, ext)
+	return filepath.Join(ensureReportsDir(), filename)
+}
+
+// exportAlerts dispatches to the exporter for the requested output format.
+func exportAlerts(format string, alerts []DependabotAlert, repo, outputFile string) {
+	switch format {
+	case "json":
+		exportJSON(alerts, repo, outputFile)
+	case "csv":
+		exportCSV(alerts, repo, outputFile)
+	case "sarif":
+		exportSARIF(alerts, repo, outputFile)
+	case "table":
+		renderTable(alerts, outputFile)
+	}
+}
 
-	dir := ensureReportsDir()
-	filePath := filepath.Join(dir, filename)
+func exportJSON(alerts []DependabotAlert, repo, outputFile string) {
+	filePath := reportFilePath(outputFile, repo, "json")
 
 	data, err := json.MarshalIndent(alerts, "", "  ")
 	if err != nil {
@@ -193,12 +801,8 @@ func exportJSON(alerts []DependabotAlert, repo string) {
 	fmt.Printf("Alerts exported to %s\n", filePath)
 }
 
-func exportCSV(alerts []DependabotAlert, repo string) {
-	dir := ensureReportsDir()
-	repoName := filepath.Base(repo)
-	timestamp := time.Now().Format("20060102-150405")
-	filename := fmt.Sprintf("%s-alerts-%s.csv", repoName, timestamp)
-	filePath := filepath.Join(dir, filename)
+func exportCSV(alerts []DependabotAlert, repo, outputFile string) {
+	filePath := reportFilePath(outputFile, repo, "csv")
 
 	file, err := os.Create(filePath)
 	if err != nil {
@@ -209,10 +813,11 @@ func exportCSV(alerts []DependabotAlert, repo string) {
 	writer := csv.NewWriter(file)
 	defer writer.Flush()
 
-	writer.Write([]string{"Dependency", "Ecosystem", "Severity", "CVE", "Manifest", "Description", "URL"})
+	writer.Write([]string{"Repository", "Dependency", "Ecosystem", "Severity", "CVE", "Manifest", "Description", "URL"})
 
 	for _, alert := range alerts {
 		err := writer.Write([]string{
+			alert.Repository,
 			alert.Dependency.Package.Name,
 			alert.Dependency.Package.Ecosystem,
 			alert.SecurityAdvisory.Severity,
@@ -229,6 +834,299 @@ func exportCSV(alerts []DependabotAlert, repo string) {
 	fmt.Printf("Exported %d alerts to %s\n", len(alerts), filePath)
 }
 
+// alertDiff holds the result of comparing a freshly fetched alert list
+// against a stored baseline.
+type alertDiff struct {
+	NewAlerts      []DependabotAlert `json:"new_alerts"`
+	ResolvedAlerts []DependabotAlert `json:"resolved_alerts"`
+}
+
+// alertKey identifies an alert for baseline comparison purposes, keyed by
+// repository, GHSA ID, manifest path, and package name rather than the
+// alert number, since the same vulnerability can resurface under a new
+// alert number. Repository is included so multi-repo/org runs don't treat
+// the same advisory in two different repos as the same alert.
+func alertKey(alert DependabotAlert) string {
+	advisoryID := alert.SecurityAdvisory.GHSAID
+	if advisoryID == "" {
+		advisoryID = getCVE(alert.SecurityAdvisory.Identifiers)
+	}
+	if advisoryID == "" || advisoryID == "N/A" {
+		// Neither a GHSA ID nor a CVE identifier is present; fall back to
+		// the advisory description so unrelated alerts on the same
+		// package/manifest don't collide under the same key.
+		advisoryID = alert.SecurityAdvisory.Description
+	}
+	return strings.Join([]string{alert.Repository, advisoryID, alert.Dependency.ManifestPath, alert.Dependency.Package.Name}, "|")
+}
+
+// loadBaseline reads a previous JSON report (as written by exportJSON) to
+// compare the current run against.
+func loadBaseline(path string) ([]DependabotAlert, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading baseline file: %w", err)
+	}
+
+	var baseline []DependabotAlert
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("error parsing baseline file: %w", err)
+	}
+
+	return baseline, nil
+}
+
+// diffAlerts compares a baseline alert list against the current one,
+// keyed by alertKey, and reports what was newly introduced and what has
+// since been resolved.
+func diffAlerts(baseline, current []DependabotAlert) alertDiff {
+	baselineKeys := make(map[string]bool, len(baseline))
+	for _, alert := range baseline {
+		baselineKeys[alertKey(alert)] = true
+	}
+
+	currentKeys := make(map[string]bool, len(current))
+	for _, alert := range current {
+		currentKeys[alertKey(alert)] = true
+	}
+
+	var diff alertDiff
+	for _, alert := range current {
+		if !baselineKeys[alertKey(alert)] {
+			diff.NewAlerts = append(diff.NewAlerts, alert)
+		}
+	}
+	for _, alert := range baseline {
+		if !currentKeys[alertKey(alert)] {
+			diff.ResolvedAlerts = append(diff.ResolvedAlerts, alert)
+		}
+	}
+
+	return diff
+}
+
+func exportDiffJSON(diff alertDiff, repo, outputFile string) {
+	filePath := reportFilePath(outputFile, repo, "json")
+
+	data, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		log.Fatalf("Error marshaling JSON: %v", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		log.Fatalf("Error writing JSON file: %v", err)
+	}
+
+	fmt.Printf("Diff exported to %s\n", filePath)
+}
+
+func exportDiffCSV(diff alertDiff, repo, outputFile string) {
+	filePath := reportFilePath(outputFile, repo, "csv")
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		log.Fatalf("Error creating CSV file: %v", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	writer.Write([]string{"Section", "Repository", "Dependency", "Ecosystem", "Severity", "CVE", "Manifest", "Description", "URL"})
+	writeDiffSection(writer, "new_alerts", diff.NewAlerts)
+	writeDiffSection(writer, "resolved_alerts", diff.ResolvedAlerts)
+
+	fmt.Printf("Diff exported (%d new, %d resolved) to %s\n", len(diff.NewAlerts), len(diff.ResolvedAlerts), filePath)
+}
+
+func writeDiffSection(writer *csv.Writer, section string, alerts []DependabotAlert) {
+	for _, alert := range alerts {
+		err := writer.Write([]string{
+			section,
+			alert.Repository,
+			alert.Dependency.Package.Name,
+			alert.Dependency.Package.Ecosystem,
+			alert.SecurityAdvisory.Severity,
+			getCVE(alert.SecurityAdvisory.Identifiers),
+			alert.Dependency.ManifestPath,
+			alert.SecurityAdvisory.Description,
+			alert.HTMLURL,
+		})
+		if err != nil {
+			log.Fatalf("Error writing CSV row: %v", err)
+		}
+	}
+}
+
+// sarifLog is a minimal SARIF 2.1.0 document, just enough to carry
+// Dependabot alerts through the GitHub code-scanning SARIF upload API and
+// render in the Security tab.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri"`
+}
+
+type sarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+func exportSARIF(alerts []DependabotAlert, repo, outputFile string) {
+	filePath := reportFilePath(outputFile, repo, "sarif")
+
+	results := make([]sarifResult, 0, len(alerts))
+	for _, alert := range alerts {
+		results = append(results, toSARIFResult(alert))
+	}
+
+	doc := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "dependabot",
+						InformationURI: "https://docs.github.com/en/code-security/dependabot",
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		log.Fatalf("Error marshaling SARIF: %v", err)
+	}
+
+	err = os.WriteFile(filePath, data, 0644)
+	if err != nil {
+		log.Fatalf("Error writing SARIF file: %v", err)
+	}
+
+	fmt.Printf("Alerts exported to %s\n", filePath)
+}
+
+// toSARIFResult converts a single alert into a SARIF result, keyed by its
+// GHSA ID (falling back to CVE) with severity mapped to a SARIF level and
+// CVSS/CWE details carried as result properties.
+func toSARIFResult(alert DependabotAlert) sarifResult {
+	ruleID := alert.SecurityAdvisory.GHSAID
+	if ruleID == "" {
+		ruleID = getCVE(alert.SecurityAdvisory.Identifiers)
+	}
+
+	cweIDs := make([]string, 0, len(alert.SecurityAdvisory.CWEs))
+	for _, cwe := range alert.SecurityAdvisory.CWEs {
+		cweIDs = append(cweIDs, cwe.CWEID)
+	}
+
+	return sarifResult{
+		RuleID:  ruleID,
+		Level:   sarifLevel(alert.SecurityAdvisory.Severity),
+		Message: sarifMessage{Text: alert.SecurityAdvisory.Description},
+		Locations: []sarifLocation{
+			{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: alert.Dependency.ManifestPath},
+				},
+			},
+		},
+		Properties: map[string]interface{}{
+			"package":                  alert.Dependency.Package.Name,
+			"ecosystem":                alert.Dependency.Package.Ecosystem,
+			"cvss_score":               alert.SecurityAdvisory.CVSS.Score,
+			"cvss_vector":              alert.SecurityAdvisory.CVSS.VectorString,
+			"cwes":                     cweIDs,
+			"vulnerable_version_range": alert.SecurityVulnerability.VulnerableVersionRange,
+			"first_patched_version":    alert.SecurityVulnerability.FirstPatchedVersion.Identifier,
+		},
+	}
+}
+
+// sarifLevel maps a Dependabot severity to the SARIF result levels GitHub
+// code scanning understands.
+func sarifLevel(severity string) string {
+	switch severity {
+	case "critical", "high":
+		return "error"
+	case "medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// renderTable prints alerts as a human-readable table to stdout, using the
+// same auto-generated/explicit path resolution as the file-based formats
+// when --output-file is set.
+func renderTable(alerts []DependabotAlert, outputFile string) {
+	var buf bytes.Buffer
+
+	table := tablewriter.NewWriter(&buf)
+	table.SetHeader([]string{"ID", "Severity", "Package", "Ecosystem", "CVE", "Manifest"})
+
+	for _, alert := range alerts {
+		table.Append([]string{
+			fmt.Sprintf("%d", alert.Number),
+			alert.SecurityAdvisory.Severity,
+			alert.Dependency.Package.Name,
+			alert.Dependency.Package.Ecosystem,
+			getCVE(alert.SecurityAdvisory.Identifiers),
+			alert.Dependency.ManifestPath,
+		})
+	}
+
+	table.Render()
+	fmt.Print(buf.String())
+
+	if outputFile == "" {
+		return
+	}
+
+	filePath := reportFilePath(outputFile, "", "txt")
+	if err := os.WriteFile(filePath, buf.Bytes(), 0644); err != nil {
+		log.Fatalf("Error writing table output file: %v", err)
+	}
+
+	fmt.Printf("Alerts exported to %s\n", filePath)
+}
+
 func getCVE(identifiers []struct {
 	Type  string `json:"type"`
 	Value string `json:"value"`